@@ -11,8 +11,11 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	blockchainTesting "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -922,14 +925,20 @@ func TestOnBlock_CanFinalize(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	depositCache, err := depositcache.New()
 	require.NoError(t, err)
+	notifier := &blockchainTesting.MockStateNotifier{}
 	cfg := &Config{
 		BeaconDB:        beaconDB,
 		StateGen:        stategen.New(beaconDB),
 		ForkChoiceStore: protoarray.New(0, 0, [32]byte{}),
 		DepositCache:    depositCache,
+		StateNotifier:   notifier,
 	}
 	service, err := NewService(ctx, cfg)
 	require.NoError(t, err)
+	// Each block finalizes at most once, so the feed never blocks on a full buffer.
+	events := make(chan *feed.Event, 4*params.BeaconConfig().SlotsPerEpoch)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
 
 	gs, keys := testutil.DeterministicGenesisState(t, 32)
 	require.NoError(t, service.saveGenesisData(ctx, gs))
@@ -951,6 +960,17 @@ func TestOnBlock_CanFinalize(t *testing.T) {
 	}
 	require.Equal(t, types.Epoch(3), service.CurrentJustifiedCheckpt().Epoch)
 	require.Equal(t, types.Epoch(2), service.FinalizedCheckpt().Epoch)
+
+	// Every finalized checkpoint update is announced on the state feed.
+	var last *statefeed.FinalizedCheckpointData
+	for len(events) > 0 {
+		e := <-events
+		require.Equal(t, feed.EventType(statefeed.FinalizedCheckpoint), e.Type)
+		last = e.Data.(*statefeed.FinalizedCheckpointData)
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, service.FinalizedCheckpt().Epoch, last.Epoch)
+	assert.Equal(t, bytesutil.ToBytes32(service.FinalizedCheckpt().Root), last.BlockRoot)
 }
 
 func TestInsertFinalizedDeposits(t *testing.T) {
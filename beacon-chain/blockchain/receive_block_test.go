@@ -9,6 +9,9 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	blockchainTesting "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
@@ -281,6 +284,57 @@ func TestService_ReceiveBlockBatch(t *testing.T) {
 	}
 }
 
+func TestService_ReceiveBlockBatch_NotifiesFinalizedCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis, keys := testutil.DeterministicGenesisState(t, 32)
+	genesisBlockRoot, err := genesis.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	notifier := &blockchainTesting.MockStateNotifier{}
+	s, err := NewService(ctx, &Config{
+		BeaconDB:        beaconDB,
+		ForkChoiceStore: protoarray.New(0, 0, genesisBlockRoot),
+		StateNotifier:   notifier,
+		StateGen:        stategen.New(beaconDB),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.saveGenesisData(ctx, genesis))
+	gBlk, err := s.cfg.BeaconDB.GenesisBlock(ctx)
+	require.NoError(t, err)
+	gRoot, err := gBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	s.finalizedCheckpt = &ethpb.Checkpoint{Root: gRoot[:]}
+
+	var blks []*ethpb.SignedBeaconBlock
+	var roots [][32]byte
+	testState := genesis.Copy()
+	for i := types.Slot(1); i <= 4*params.BeaconConfig().SlotsPerEpoch; i++ {
+		blk, err := testutil.GenerateFullBlock(testState, keys, testutil.DefaultBlockGenConfig(), i)
+		require.NoError(t, err)
+		testState, err = state.ExecuteStateTransition(ctx, testState, blk)
+		require.NoError(t, err)
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks = append(blks, blk)
+		roots = append(roots, r)
+	}
+
+	events := make(chan *feed.Event, len(blks)*2)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+	require.NoError(t, s.ReceiveBlockBatch(ctx, blks, roots))
+
+	var finalized []*statefeed.FinalizedCheckpointData
+	for len(events) > 0 {
+		if e := <-events; e.Type == statefeed.FinalizedCheckpoint {
+			finalized = append(finalized, e.Data.(*statefeed.FinalizedCheckpointData))
+		}
+	}
+	require.Equal(t, 1, len(finalized))
+	assert.Equal(t, testState.FinalizedCheckpoint().Epoch, finalized[0].Epoch)
+	assert.Equal(t, bytesutil.ToBytes32(testState.FinalizedCheckpoint().Root), finalized[0].BlockRoot)
+}
+
 func TestService_HasInitSyncBlock(t *testing.T) {
 	s, err := NewService(context.Background(), &Config{StateNotifier: &blockchainTesting.MockStateNotifier{}})
 	require.NoError(t, err)
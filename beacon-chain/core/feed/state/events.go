@@ -22,6 +22,8 @@ const (
 	// Reorg is an event sent when the new head state's slot after a block
 	// transition is lower than its previous head state slot value.
 	Reorg
+	// FinalizedCheckpoint is sent when a processed block advances the finalized checkpoint.
+	FinalizedCheckpoint
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -63,3 +65,11 @@ type ReorgData struct {
 	// OldSlot is the slot of the head state before the reorg.
 	OldSlot types.Slot
 }
+
+// FinalizedCheckpointData is the data sent with FinalizedCheckpoint events.
+type FinalizedCheckpointData struct {
+	// Epoch of the new finalized checkpoint.
+	Epoch types.Epoch
+	// BlockRoot of the new finalized checkpoint.
+	BlockRoot [32]byte
+}
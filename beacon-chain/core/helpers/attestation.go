@@ -71,15 +71,11 @@ func IsAggregator(committeeCount uint64, slotSig []byte) (bool, error) {
 //    signatures = [attestation.signature for attestation in attestations]
 //    return bls_aggregate_signatures(signatures)
 func AggregateSignature(attestations []*ethpb.Attestation) (bls.Signature, error) {
-	sigs := make([]bls.Signature, len(attestations))
-	var err error
+	sigs := make([][]byte, len(attestations))
 	for i := 0; i < len(sigs); i++ {
-		sigs[i], err = bls.SignatureFromBytes(attestations[i].Signature)
-		if err != nil {
-			return nil, err
-		}
+		sigs[i] = attestations[i].Signature
 	}
-	return bls.AggregateSignatures(sigs), nil
+	return bls.AggregateSignaturesFromBytes(sigs)
 }
 
 // IsAggregated returns true if the attestation is an aggregated attestation,
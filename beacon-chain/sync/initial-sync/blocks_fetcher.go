@@ -289,10 +289,16 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 		Step:      1,
 	}
 	for i := 0; i < len(peers); i++ {
-		if blocks, err := f.requestBlocks(ctx, req, peers[i]); err == nil {
+		blocks, err := f.requestBlocks(ctx, req, peers[i])
+		if err == nil {
 			f.p2p.Peers().Scorers().BlockProviderScorer().Touch(peers[i])
 			return blocks, peers[i], err
 		}
+		// Peer responded with blocks outside of requested range (or otherwise malformed response).
+		if errors.Is(err, prysmsync.ErrInvalidFetchedData) {
+			log.WithError(err).WithField("peer", peers[i]).Debug("Peer returned invalid blocks")
+			f.p2p.Peers().Scorers().BadResponsesScorer().Increment(peers[i])
+		}
 	}
 	return nil, "", errNoPeersAvailable
 }
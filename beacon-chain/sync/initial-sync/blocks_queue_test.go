@@ -15,7 +15,6 @@ import (
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
-	beaconsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
@@ -492,27 +491,6 @@ func TestBlocksQueue_onDataReceivedEvent(t *testing.T) {
 		assert.Equal(t, stateNew, queue.smm.machines[250].state)
 	})
 
-	t.Run("invalid data returned", func(t *testing.T) {
-		queue := newBlocksQueue(ctx, &blocksQueueConfig{
-			blocksFetcher:       fetcher,
-			chain:               mc,
-			highestExpectedSlot: types.Slot(blockBatchLimit),
-		})
-
-		hook := logTest.NewGlobal()
-		defer hook.Reset()
-		handlerFn := queue.onDataReceivedEvent(ctx)
-		updatedState, err := handlerFn(&stateMachine{
-			state: stateScheduled,
-		}, &fetchRequestResponse{
-			pid: "abc",
-			err: beaconsync.ErrInvalidFetchedData,
-		})
-		assert.ErrorContains(t, beaconsync.ErrInvalidFetchedData.Error(), err)
-		assert.Equal(t, stateScheduled, updatedState)
-		assert.LogsContain(t, hook, "msg=\"Peer is penalized for invalid blocks\" pid=ZiCa")
-	})
-
 	t.Run("transition ok", func(t *testing.T) {
 		queue := newBlocksQueue(ctx, &blocksQueueConfig{
 			blocksFetcher:       fetcher,
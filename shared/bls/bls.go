@@ -69,6 +69,20 @@ func AggregateSignatures(sigs []common.Signature) common.Signature {
 	return herumi.AggregateSignatures(sigs)
 }
 
+// AggregateSignaturesFromBytes deserializes raw signatures, including their
+// subgroup checks, and aggregates them into a single signature.
+func AggregateSignaturesFromBytes(sigs [][]byte) (common.Signature, error) {
+	rawSigs := make([]Signature, len(sigs))
+	var err error
+	for i, s := range sigs {
+		rawSigs[i], err = SignatureFromBytes(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal signature %d", i)
+		}
+	}
+	return AggregateSignatures(rawSigs), nil
+}
+
 // VerifyMultipleSignatures verifies multiple signatures for distinct messages securely.
 func VerifyMultipleSignatures(sigs [][]byte, msgs [][32]byte, pubKeys []common.PublicKey) (bool, error) {
 	if featureconfig.Get().EnableBlst {
@@ -87,7 +101,8 @@ func VerifyMultipleSignatures(sigs [][]byte, msgs [][32]byte, pubKeys []common.P
 	return herumi.VerifyMultipleSignatures(rawSigs, msgs, pubKeys)
 }
 
-// NewAggregateSignature creates a blank aggregate signature.
+// NewAggregateSignature creates a blank aggregate signature, i.e. the point at
+// infinity, which signatures can be incrementally added to.
 func NewAggregateSignature() common.Signature {
 	if featureconfig.Get().EnableBlst {
 		return blst.NewAggregateSignature()
@@ -70,3 +70,21 @@ func TestDisallowZeroPublicKeys_AggregatePubkeys(t *testing.T) {
 		require.Equal(t, common.ErrInfinitePubKey, err)
 	})
 }
+
+func TestAggregateSignaturesFromBytes(t *testing.T) {
+	msg := [32]byte{'h', 'e', 'l', 'l', 'o'}
+	var pubKeys []common.PublicKey
+	var rawSigs [][]byte
+	for i := 0; i < 3; i++ {
+		priv, err := RandKey()
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, priv.PublicKey())
+		rawSigs = append(rawSigs, priv.Sign(msg[:]).Marshal())
+	}
+	aggSig, err := AggregateSignaturesFromBytes(rawSigs)
+	require.NoError(t, err)
+	require.Equal(t, true, aggSig.FastAggregateVerify(pubKeys, msg))
+
+	_, err = AggregateSignaturesFromBytes(append(rawSigs, make([]byte, 96)))
+	require.ErrorContains(t, "could not unmarshal signature 3", err)
+}
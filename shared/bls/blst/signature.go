@@ -120,10 +120,10 @@ func (s *Signature) FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte
 	return s.s.FastAggregateVerify(true, rawKeys, msg[:], dst)
 }
 
-// NewAggregateSignature creates a blank aggregate signature.
+// NewAggregateSignature creates a blank aggregate signature, i.e. the point at
+// infinity, which signatures can be incrementally added to.
 func NewAggregateSignature() common.Signature {
-	sig := blst.HashToG2([]byte{'m', 'o', 'c', 'k'}, dst).ToAffine()
-	return &Signature{s: sig}
+	return &Signature{s: new(blstSignature)}
 }
 
 // AggregateSignatures converts a list of signatures into a single, aggregated sig.
@@ -218,6 +218,20 @@ func (s *Signature) Copy() common.Signature {
 	return &Signature{s: &sign}
 }
 
+// Aggregate adds another signature into this one.
+func (s *Signature) Aggregate(s2 common.Signature) common.Signature {
+	if featureconfig.Get().SkipBLSVerify {
+		return s
+	}
+
+	agg := new(blstAggregateSignature)
+	// No group check here since it is checked at decompression time
+	agg.Add(s.s, false)
+	agg.Add(s2.(*Signature).s, false)
+	s.s = agg.ToAffine()
+	return s
+}
+
 // VerifyCompressed verifies that the compressed signature and pubkey
 // are valid from the message provided.
 func VerifyCompressed(signature []byte, pub []byte, msg []byte) bool {
@@ -83,6 +83,11 @@ func (s Signature) Copy() common.Signature {
 	panic(err)
 }
 
+// Aggregate -- stub
+func (s Signature) Aggregate(_ common.Signature) common.Signature {
+	panic(err)
+}
+
 // SecretKeyFromBytes -- stub
 func SecretKeyFromBytes(_ []byte) (SecretKey, error) {
 	panic(err)
@@ -28,4 +28,5 @@ type Signature interface {
 	FastAggregateVerify(pubKeys []PublicKey, msg [32]byte) bool
 	Marshal() []byte
 	Copy() Signature
+	Aggregate(s2 Signature) Signature
 }
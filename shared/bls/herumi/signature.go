@@ -112,9 +112,10 @@ func (s *Signature) FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte
 	return s.s.FastAggregateVerify(rawKeys, msg[:])
 }
 
-// NewAggregateSignature creates a blank aggregate signature.
+// NewAggregateSignature creates a blank aggregate signature, i.e. the point at
+// infinity, which signatures can be incrementally added to.
 func NewAggregateSignature() common.Signature {
-	return &Signature{s: bls12.HashAndMapToSignature([]byte{'m', 'o', 'c', 'k'})}
+	return &Signature{s: &bls12.Sign{}}
 }
 
 // AggregateSignatures converts a list of signatures into a single, aggregated sig.
@@ -217,3 +218,12 @@ func (s *Signature) Copy() common.Signature {
 	sign := *s.s
 	return &Signature{s: &sign}
 }
+
+// Aggregate adds another signature into this one.
+func (s *Signature) Aggregate(s2 common.Signature) common.Signature {
+	if featureconfig.Get().SkipBLSVerify {
+		return s
+	}
+	s.s.Add(s2.(*Signature).s)
+	return s
+}
@@ -75,8 +75,16 @@ func DepositInput(depositKey, withdrawalKey bls.SecretKey, amountInGwei uint64)
 //   withdrawal_credentials[1:] == hash(withdrawal_pubkey)[1:]
 // where withdrawal_credentials is of type bytes32.
 func WithdrawalCredentialsHash(withdrawalKey bls.SecretKey) []byte {
-	h := hashutil.Hash(withdrawalKey.PublicKey().Marshal())
-	return append([]byte{params.BeaconConfig().BLSWithdrawalPrefixByte}, h[1:]...)[:32]
+	creds := WithdrawalCredentialsFromPubkey(withdrawalKey.PublicKey())
+	return creds[:]
+}
+
+// WithdrawalCredentialsFromPubkey derives BLS withdrawal credentials from a
+// withdrawal public key, for when the withdrawal secret key is kept offline.
+func WithdrawalCredentialsFromPubkey(withdrawalPubkey bls.PublicKey) [32]byte {
+	h := hashutil.Hash(withdrawalPubkey.Marshal())
+	h[0] = params.BeaconConfig().BLSWithdrawalPrefixByte
+	return h
 }
 
 // VerifyDepositSignature verifies the correctness of Eth1 deposit BLS signature
@@ -3,6 +3,7 @@ package depositutil_test
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
@@ -73,3 +74,15 @@ func TestVerifyDepositSignature_InvalidSig(t *testing.T) {
 		t.Fatal("Deposit Verification succeeds with a invalid signature")
 	}
 }
+
+func TestWithdrawalCredentialsFromPubkey(t *testing.T) {
+	pubKey, err := bls.PublicKeyFromBytes(hexutil.MustDecode("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"))
+	require.NoError(t, err)
+	creds := depositutil.WithdrawalCredentialsFromPubkey(pubKey)
+	assert.DeepEqual(t, hexutil.MustDecode("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"), creds[:])
+
+	k, err := bls.RandKey()
+	require.NoError(t, err)
+	creds = depositutil.WithdrawalCredentialsFromPubkey(k.PublicKey())
+	assert.DeepEqual(t, depositutil.WithdrawalCredentialsHash(k), creds[:])
+}
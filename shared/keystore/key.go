@@ -33,6 +33,10 @@ import (
 const (
 	keyHeaderKDF = "scrypt"
 
+	// blsKeyScheme marks keystores holding a BLS12-381 secret key, as opposed
+	// to the secp256k1 keys found in geth keystores sharing the same envelope.
+	blsKeyScheme = "bls12-381"
+
 	// LightScryptN is the N parameter of Scrypt encryption algorithm, using 4MB
 	// memory and taking approximately 100ms CPU time on a modern processor.
 	LightScryptN = 1 << 12
@@ -43,6 +47,15 @@ const (
 
 	scryptR     = 8
 	scryptDKLen = 32
+
+	// MinPBKDF2Iterations is the lowest PBKDF2 iteration count returned by
+	// TunePBKDF2Iterations, matching the EIP-2335 reference parameters.
+	MinPBKDF2Iterations = 1 << 18
+
+	pbkdf2KDF             = "pbkdf2"
+	pbkdf2PRF             = "hmac-sha256"
+	pbkdf2ProbeIterations = 1 << 14
+	scryptProbeN          = 1 << 10
 )
 
 // Key is the object that stores all the user data related to their public/secret keys.
@@ -82,6 +95,17 @@ type cryptoJSON struct {
 	KDF          string                 `json:"kdf"`
 	KDFParams    map[string]interface{} `json:"kdfparams"`
 	MAC          string                 `json:"mac"`
+	Scheme       string                 `json:"scheme,omitempty"`
+}
+
+// eip2335KeystoreJSON is the EIP-2335 keystore layout, as also used by the
+// validator client's imported keymanager.
+type eip2335KeystoreJSON struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	ID      string                 `json:"uuid"`
+	Pubkey  string                 `json:"pubkey"`
+	Version uint                   `json:"version"`
+	Name    string                 `json:"name"`
 }
 
 type cipherparamsJSON struct {
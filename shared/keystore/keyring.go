@@ -0,0 +1,85 @@
+package keystore
+
+import (
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var _ keyStore = (*KeyringStore)(nil)
+
+// keyringService is the service name keystore passwords are filed under.
+const keyringService = "prysm-keystore"
+
+// ErrKeyringEntryNotFound is returned by a Keyring holding no password for a keystore file.
+var ErrKeyringEntryNotFound = errors.New("keyring entry not found")
+
+// Keyring stores secrets outside of the keystore directory, typically backed by
+// the operating system keyring.
+type Keyring interface {
+	// Get returns the secret stored for service and user, or ErrKeyringEntryNotFound.
+	Get(service, user string) (string, error)
+	// Set stores the secret for service and user, replacing any previous one.
+	Set(service, user, secret string) error
+}
+
+// KeyringStore is a keystore which keeps the passwords of its key files in a
+// Keyring, so that keys can be unlocked across restarts without prompting.
+// Only passwords are placed in the keyring, keys stay encrypted on disk.
+type KeyringStore struct {
+	Keystore
+	keyring Keyring
+	prompt  func(filename string) (string, error)
+}
+
+// NewKeyringStore wraps ks so that passwords are looked up in keyring, falling
+// back to prompt for key files which have no usable keyring entry.
+func NewKeyringStore(ks Keystore, keyring Keyring, prompt func(filename string) (string, error)) *KeyringStore {
+	return &KeyringStore{
+		Keystore: ks,
+		keyring:  keyring,
+		prompt:   prompt,
+	}
+}
+
+// GetKey decrypts the key stored at filename. An empty password is resolved
+// from the keyring, or else by prompting, in which case the prompted password
+// is saved to the keyring for the next unlock.
+func (ks *KeyringStore) GetKey(filename, password string) (*Key, error) {
+	if password != "" {
+		return ks.Keystore.GetKey(filename, password)
+	}
+	password, err := ks.keyring.Get(keyringService, filename)
+	switch {
+	case err == nil:
+		key, err := ks.Keystore.GetKey(filename, password)
+		if !errors.Is(err, ErrDecrypt) {
+			return key, err
+		}
+		// The key file was re-encrypted under another password, ask for it again.
+		log.WithField("keyfile", filename).Warn("Password in keyring does not unlock key file")
+	case !errors.Is(err, ErrKeyringEntryNotFound):
+		return nil, err
+	}
+
+	password, err = ks.prompt(filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ks.Keystore.GetKey(filename, password)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.keyring.Set(keyringService, filename, password); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// StoreKey encrypts the key into filename and saves auth in the keyring.
+func (ks *KeyringStore) StoreKey(filename string, key *Key, auth string) error {
+	if err := ks.Keystore.StoreKey(filename, key, auth); err != nil {
+		return err
+	}
+	return ks.keyring.Set(keyringService, filename, auth)
+}
@@ -0,0 +1,89 @@
+package keystore
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+type fakeKeyring struct {
+	secrets map[string]string
+}
+
+func (k *fakeKeyring) Get(service, user string) (string, error) {
+	secret, ok := k.secrets[service+"/"+user]
+	if !ok {
+		return "", ErrKeyringEntryNotFound
+	}
+	return secret, nil
+}
+
+func (k *fakeKeyring) Set(service, user, secret string) error {
+	k.secrets[service+"/"+user] = secret
+	return nil
+}
+
+func TestKeyringStore_AutoUnlock(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	ring := &fakeKeyring{secrets: make(map[string]string)}
+	noPrompt := func(string) (string, error) {
+		return "", errors.New("unexpected prompt")
+	}
+	ks := NewKeyringStore(Keystore{scryptN: LightScryptN, scryptP: LightScryptP}, ring, noPrompt)
+
+	key, err := NewKey()
+	require.NoError(t, err)
+	require.NoError(t, ks.StoreKey(filename, key, "password"))
+
+	// A restarted store with the same keyring unlocks without prompting.
+	ks = NewKeyringStore(Keystore{}, ring, noPrompt)
+	decryptedKey, err := ks.GetKey(filename, "")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), decryptedKey.SecretKey.Marshal())
+}
+
+func TestKeyringStore_MissingEntryPrompts(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	key, err := NewKey()
+	require.NoError(t, err)
+	plain := Keystore{scryptN: LightScryptN, scryptP: LightScryptP}
+	require.NoError(t, plain.StoreKey(filename, key, "password"))
+
+	ring := &fakeKeyring{secrets: make(map[string]string)}
+	prompts := 0
+	ks := NewKeyringStore(plain, ring, func(string) (string, error) {
+		prompts++
+		return "password", nil
+	})
+	decryptedKey, err := ks.GetKey(filename, "")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), decryptedKey.SecretKey.Marshal())
+	assert.Equal(t, 1, prompts)
+
+	// The prompted password is remembered.
+	_, err = ks.GetKey(filename, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, prompts)
+}
+
+func TestKeyringStore_StalePasswordPrompts(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	key, err := NewKey()
+	require.NoError(t, err)
+	plain := Keystore{scryptN: LightScryptN, scryptP: LightScryptP}
+	require.NoError(t, plain.StoreKey(filename, key, "password"))
+
+	ring := &fakeKeyring{secrets: map[string]string{keyringService + "/" + filename: "stale"}}
+	prompts := 0
+	ks := NewKeyringStore(plain, ring, func(string) (string, error) {
+		prompts++
+		return "password", nil
+	})
+	_, err = ks.GetKey(filename, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, prompts)
+	assert.Equal(t, "password", ring.secrets[keyringService+"/"+filename])
+}
@@ -28,14 +28,17 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/minio/sha256-simd"
 	"github.com/pborman/uuid"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	log "github.com/sirupsen/logrus"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
@@ -43,6 +46,8 @@ import (
 var (
 	// ErrDecrypt is the standard error message when decryption is a failure.
 	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+	// ErrWrongKeyScheme is returned when a keystore does not hold a BLS12-381 key.
+	ErrWrongKeyScheme = errors.New("keystore does not hold a BLS12-381 key")
 )
 
 // Keystore defines a keystore with a directory path and scrypt values.
@@ -137,6 +142,151 @@ func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error)
 		return nil, err
 	}
 
+	scryptParamsJSON := make(map[string]interface{}, 5)
+	scryptParamsJSON["n"] = scryptN
+	scryptParamsJSON["r"] = scryptR
+	scryptParamsJSON["p"] = scryptP
+	scryptParamsJSON["dklen"] = scryptDKLen
+	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
+	return encryptWithDerivedKey(key, derivedKey, keyHeaderKDF, scryptParamsJSON)
+}
+
+// EncryptKeyPBKDF2 encrypts a key like EncryptKey, but derives the encryption
+// key with PBKDF2-HMAC-SHA256 over c iterations instead of scrypt. See
+// TunePBKDF2Iterations for picking c, which must be at least MinPBKDF2Iterations.
+func EncryptKeyPBKDF2(key *Key, password string, c int) ([]byte, error) {
+	if c < MinPBKDF2Iterations {
+		return nil, fmt.Errorf("PBKDF2 iteration count %d is below the minimum of %d", c, MinPBKDF2Iterations)
+	}
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.New("reading from crypto/rand failed: " + err.Error())
+	}
+
+	derivedKey := pbkdf2.Key([]byte(password), salt, c, scryptDKLen, sha256.New)
+
+	pbkdf2ParamsJSON := make(map[string]interface{}, 4)
+	pbkdf2ParamsJSON["c"] = c
+	pbkdf2ParamsJSON["prf"] = pbkdf2PRF
+	pbkdf2ParamsJSON["dklen"] = scryptDKLen
+	pbkdf2ParamsJSON["salt"] = hex.EncodeToString(salt)
+	return encryptWithDerivedKey(key, derivedKey, pbkdf2KDF, pbkdf2ParamsJSON)
+}
+
+// TunePBKDF2Iterations measures PBKDF2-HMAC-SHA256 on this machine and returns
+// the iteration count expected to take roughly target to derive a key. The
+// result is never lower than MinPBKDF2Iterations.
+func TunePBKDF2Iterations(target time.Duration) int {
+	salt := make([]byte, 32)
+	start := time.Now()
+	pbkdf2.Key([]byte("keystore-tuning"), salt, pbkdf2ProbeIterations, scryptDKLen, sha256.New)
+	elapsed := time.Since(start)
+	if elapsed <= 0 {
+		elapsed = 1
+	}
+	c := int64(target) / int64(elapsed) * pbkdf2ProbeIterations
+	if c < MinPBKDF2Iterations {
+		return MinPBKDF2Iterations
+	}
+	if c > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(c)
+}
+
+// EstimateDecryptCost estimates how long DecryptKey would take on this machine
+// for keyJSON. It times the file's KDF at reduced cost parameters and scales the
+// result up, so the real key is never derived.
+func EstimateDecryptCost(keyJSON []byte) (time.Duration, error) {
+	eip2335Key := new(eip2335KeystoreJSON)
+	if err := json.Unmarshal(keyJSON, eip2335Key); err != nil {
+		return 0, err
+	}
+	if eip2335Key.Version == keystorev4.New().Version() {
+		kdf, kdfParams, err := eip2335KDF(eip2335Key)
+		if err != nil {
+			return 0, err
+		}
+		return estimateKDFCost(kdf, kdfParams)
+	}
+
+	k := new(encryptedKeyJSON)
+	if err := json.Unmarshal(keyJSON, k); err != nil {
+		return 0, err
+	}
+	return estimateKDFCost(k.Crypto.KDF, k.Crypto.KDFParams)
+}
+
+// eip2335KDF returns the KDF function and parameters of an EIP-2335 keystore.
+func eip2335KDF(k *eip2335KeystoreJSON) (string, map[string]interface{}, error) {
+	kdfModule, ok := k.Crypto["kdf"].(map[string]interface{})
+	if !ok {
+		return "", nil, errors.New("EIP-2335 keystore has no KDF module")
+	}
+	kdf, ok := kdfModule["function"].(string)
+	if !ok {
+		return "", nil, errors.New("EIP-2335 keystore has no KDF function")
+	}
+	kdfParams, ok := kdfModule["params"].(map[string]interface{})
+	if !ok {
+		return "", nil, errors.New("EIP-2335 keystore has no KDF parameters")
+	}
+	return kdf, kdfParams, nil
+}
+
+func estimateKDFCost(kdf string, kdfParams map[string]interface{}) (time.Duration, error) {
+	salt := make([]byte, 32)
+	switch kdf {
+	case keyHeaderKDF:
+		n, err := kdfIntParam(kdfParams, "n")
+		if err != nil {
+			return 0, err
+		}
+		r, err := kdfIntParam(kdfParams, "r")
+		if err != nil {
+			return 0, err
+		}
+		p, err := kdfIntParam(kdfParams, "p")
+		if err != nil {
+			return 0, err
+		}
+		// Mirror the limits scrypt.Key enforces, so files DecryptKey rejects are
+		// not given an estimate.
+		if n <= 1 || n&(n-1) != 0 || r <= 0 || p <= 0 || uint64(r)*uint64(p) >= 1<<30 {
+			return 0, fmt.Errorf("invalid scrypt parameters: n=%d, r=%d, p=%d", n, r, p)
+		}
+		probeN := scryptProbeN
+		if n < probeN {
+			probeN = n
+		}
+		// Memory grows with r, so the probe always runs at the default block size
+		// and the result is scaled linearly to the file's r.
+		start := time.Now()
+		if _, err := scrypt.Key([]byte("keystore-estimate"), salt, probeN, scryptR, 1, scryptDKLen); err != nil {
+			return 0, err
+		}
+		factor := float64(n/probeN) * float64(p) * float64(r) / scryptR
+		return scaleDuration(time.Since(start), factor), nil
+	case pbkdf2KDF:
+		c, err := kdfIntParam(kdfParams, "c")
+		if err != nil {
+			return 0, err
+		}
+		if c <= 0 {
+			return 0, fmt.Errorf("invalid PBKDF2 iteration count: %d", c)
+		}
+		probeC := pbkdf2ProbeIterations
+		if c < probeC {
+			probeC = c
+		}
+		start := time.Now()
+		pbkdf2.Key([]byte("keystore-estimate"), salt, probeC, scryptDKLen, sha256.New)
+		return scaleDuration(time.Since(start), float64(c)/float64(probeC)), nil
+	}
+	return 0, fmt.Errorf("unsupported KDF: %s", kdf)
+}
+
+func encryptWithDerivedKey(key *Key, derivedKey []byte, kdf string, kdfParams map[string]interface{}) ([]byte, error) {
 	encryptKey := derivedKey[:16]
 	keyBytes := key.SecretKey.Marshal()
 
@@ -152,13 +302,6 @@ func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error)
 
 	mac := Keccak256(derivedKey[16:32], cipherText)
 
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
@@ -167,9 +310,10 @@ func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error)
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf,
+		KDFParams:    kdfParams,
 		MAC:          hex.EncodeToString(mac),
+		Scheme:       blsKeyScheme,
 	}
 	encryptedJSON := encryptedKeyJSON{
 		hex.EncodeToString(key.PublicKey.Marshal()),
@@ -180,14 +324,26 @@ func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error)
 }
 
 // DecryptKey decrypts a key from a JSON blob, returning the private key itself.
+// Both the geth-style keystore format and EIP-2335 keystores are accepted.
 func DecryptKey(keyJSON []byte, password string) (*Key, error) {
 	var keyBytes, keyID []byte
 	var err error
 
+	eip2335Key := new(eip2335KeystoreJSON)
+	if err := json.Unmarshal(keyJSON, eip2335Key); err != nil {
+		return nil, err
+	}
+	if encryptor := keystorev4.New(); eip2335Key.Version == encryptor.Version() {
+		return decryptEIP2335Key(encryptor, eip2335Key, password)
+	}
+
 	k := new(encryptedKeyJSON)
 	if err := json.Unmarshal(keyJSON, k); err != nil {
 		return nil, err
 	}
+	if err := checkKeyScheme(k); err != nil {
+		return nil, err
+	}
 
 	keyBytes, keyID, err = decryptKeyJSON(k, password)
 	// Handle any decryption errors and return the key
@@ -207,42 +363,198 @@ func DecryptKey(keyJSON []byte, password string) (*Key, error) {
 	}, nil
 }
 
+// decryptEIP2335Key decrypts a keystore in the EIP-2335 format, such as the ones
+// written by ConvertToEIP2335 or the validator client's imported keymanager.
+func decryptEIP2335Key(encryptor *keystorev4.Encryptor, k *eip2335KeystoreJSON, password string) (*Key, error) {
+	kdf, _, err := eip2335KDF(k)
+	if err != nil {
+		return nil, err
+	}
+	// Key derivation dominates the cost of decrypting, so time the whole call.
+	start := time.Now()
+	keyBytes, err := encryptor.Decrypt(k.Crypto, password)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid checksum") {
+			return nil, ErrDecrypt
+		}
+		return nil, err
+	}
+	recordKDFDuration(kdf, time.Since(start))
+
+	secretKey, err := bls.SecretKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := secretKey.PublicKey()
+	if k.Pubkey != "" && k.Pubkey != hex.EncodeToString(publicKey.Marshal()) {
+		return nil, fmt.Errorf("keystore public key %s does not match the decrypted secret key", k.Pubkey)
+	}
+
+	return &Key{
+		ID:        uuid.Parse(k.ID),
+		PublicKey: publicKey,
+		SecretKey: secretKey,
+	}, nil
+}
+
+// RefreshKeystoreIV re-encrypts the key stored at filename under a fresh random
+// IV. The KDF parameters and salt are kept, so the derived key and the password
+// stay the same, and the file is rewritten atomically. Only keystores in the
+// geth-style format written by this package are supported.
+func RefreshKeystoreIV(filename, password string) error {
+	// #nosec G304
+	keyJSON, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	eip2335Key := new(eip2335KeystoreJSON)
+	if err := json.Unmarshal(keyJSON, eip2335Key); err != nil {
+		return err
+	}
+	if eip2335Key.Version == keystorev4.New().Version() {
+		return errors.New("refreshing the IV of EIP-2335 keystores is not supported")
+	}
+	// Refuse files carrying fields the rewrite would silently drop.
+	decoder := json.NewDecoder(bytes.NewReader(keyJSON))
+	decoder.DisallowUnknownFields()
+	k := new(encryptedKeyJSON)
+	if err := decoder.Decode(k); err != nil {
+		return fmt.Errorf("unsupported keystore format: %v", err)
+	}
+	if err := checkKeyScheme(k); err != nil {
+		return err
+	}
+	derivedKey, err := kdfKey(k.Crypto, password)
+	if err != nil {
+		return err
+	}
+	keyBytes, err := decryptWithDerivedKey(k, derivedKey)
+	if err != nil {
+		return err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return errors.New("reading from crypto/rand failed: " + err.Error())
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], keyBytes, iv)
+	if err != nil {
+		return err
+	}
+	mac := Keccak256(derivedKey[16:32], cipherText)
+
+	k.Crypto.CipherText = hex.EncodeToString(cipherText)
+	k.Crypto.CipherParams.IV = hex.EncodeToString(iv)
+	k.Crypto.MAC = hex.EncodeToString(mac)
+	newKeyJSON, err := json.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(filename, newKeyJSON)
+}
+
+// ConvertToEIP2335 decrypts the keystore at filename and re-encrypts its key
+// into the EIP-2335 keystore format under the same password.
+func ConvertToEIP2335(filename, password string) ([]byte, error) {
+	// #nosec G304
+	keyJSON, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, err
+	}
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(key.SecretKey.Marshal(), password)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&eip2335KeystoreJSON{
+		Crypto:  cryptoFields,
+		ID:      key.ID.String(),
+		Pubkey:  hex.EncodeToString(key.PublicKey.Marshal()),
+		Version: encryptor.Version(),
+		Name:    encryptor.Name(),
+	})
+}
+
+// checkKeyScheme rejects keystores which do not hold a BLS12-381 key.
+func checkKeyScheme(k *encryptedKeyJSON) error {
+	// Files written before the scheme marker existed are accepted as long as they
+	// carry a BLS public key; geth secp256k1 files carry an address instead.
+	if k.Crypto.Scheme != "" && k.Crypto.Scheme != blsKeyScheme {
+		return fmt.Errorf("%w: got scheme %s", ErrWrongKeyScheme, k.Crypto.Scheme)
+	}
+	if k.Crypto.Scheme == "" && k.PublicKey == "" {
+		return ErrWrongKeyScheme
+	}
+	return nil
+}
+
 func decryptKeyJSON(keyProtected *encryptedKeyJSON, auth string) (keyBytes, keyID []byte, err error) {
 	keyID = uuid.Parse(keyProtected.ID)
-	if keyProtected.Crypto.Cipher != "aes-128-ctr" {
-		return nil, nil, fmt.Errorf("cipher not supported: %v", keyProtected.Crypto.Cipher)
+	start := time.Now()
+	derivedKey, err := kdfKey(keyProtected.Crypto, auth)
+	if err != nil {
+		return nil, nil, err
 	}
+	recordKDFDuration(keyProtected.Crypto.KDF, time.Since(start))
 
-	mac, err := hex.DecodeString(keyProtected.Crypto.MAC)
+	keyBytes, err = decryptWithDerivedKey(keyProtected, derivedKey)
 	if err != nil {
 		return nil, nil, err
 	}
+	return keyBytes, keyID, nil
+}
+
+// decryptWithDerivedKey checks the MAC of the keystore against the already
+// derived key and decrypts the secret key bytes.
+func decryptWithDerivedKey(keyProtected *encryptedKeyJSON, derivedKey []byte) ([]byte, error) {
+	if keyProtected.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("cipher not supported: %v", keyProtected.Crypto.Cipher)
+	}
 
-	iv, err := hex.DecodeString(keyProtected.Crypto.CipherParams.IV)
+	mac, err := hex.DecodeString(keyProtected.Crypto.MAC)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	cipherText, err := hex.DecodeString(keyProtected.Crypto.CipherText)
+	iv, err := hex.DecodeString(keyProtected.Crypto.CipherParams.IV)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	derivedKey, err := kdfKey(keyProtected.Crypto, auth)
+	cipherText, err := hex.DecodeString(keyProtected.Crypto.CipherText)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	calculatedMAC := Keccak256(derivedKey[16:32], cipherText)
 	if !bytes.Equal(calculatedMAC, mac) {
-		return nil, nil, ErrDecrypt
+		return nil, ErrDecrypt
 	}
 
-	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
-	if err != nil {
-		return nil, nil, err
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// slowKDFWarningThreshold is the key derivation time above which unlocking a
+// keystore is reported as slow.
+const slowKDFWarningThreshold = 10 * time.Second
+
+// recordKDFDuration exports the time spent deriving a decryption key, and warns
+// when it is long enough to suggest misconfigured KDF parameters.
+func recordKDFDuration(kdf string, duration time.Duration) {
+	kdfDerivationDuration.Observe(duration.Seconds())
+	logger := log.WithFields(log.Fields{
+		"kdf":      kdf,
+		"duration": duration,
+	})
+	if duration > slowKDFWarningThreshold {
+		logger.Warn("Keystore key derivation is slow, consider lowering the KDF parameters")
+		return
 	}
-	return plainText, keyID, nil
+	logger.Debug("Derived keystore decryption key")
 }
 
 func kdfKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
@@ -259,13 +571,13 @@ func kdfKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
 		p := ensureInt(cryptoJSON.KDFParams["p"])
 		return scrypt.Key(authArray, salt, n, r, p, dkLen)
 
-	} else if cryptoJSON.KDF == "pbkdf2" {
+	} else if cryptoJSON.KDF == pbkdf2KDF {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
 		prf, ok := cryptoJSON.KDFParams["prf"].(string)
 		if !ok {
 			return nil, errors.New("KDFParams are not type string")
 		}
-		if prf != "hmac-sha256" {
+		if prf != pbkdf2PRF {
 			return nil, fmt.Errorf("unsupported PBKDF2 PRF: %s", prf)
 		}
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
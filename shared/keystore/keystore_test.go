@@ -2,15 +2,23 @@ package keystore
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 )
 
 func TestStoreAndGetKey(t *testing.T) {
@@ -93,3 +101,376 @@ func TestGetSymlinkedKeys(t *testing.T) {
 		require.Equal(t, true, bytes.Equal(s.SecretKey.Marshal(), key.SecretKey.Marshal()))
 	}
 }
+
+// eip2335Keystore holds the secret key of the EIP-2335 test vectors, encrypted
+// by keystorev4 under the password "testpassword".
+const eip2335Keystore = `{
+  "crypto": {
+    "kdf": {
+      "function": "pbkdf2",
+      "params": {
+        "dklen": 32,
+        "c": 262144,
+        "prf": "hmac-sha256",
+        "salt": "9202125521253f1cc9d3d49304328c6944a0191c08b3a1f4ee70ab3a83d19e2e"
+      },
+      "message": ""
+    },
+    "checksum": {
+      "function": "sha256",
+      "params": {},
+      "message": "7d2eee06634bf1cf8ef0f288977321780fcaa4d04e8dd16dc83083f470535105"
+    },
+    "cipher": {
+      "function": "aes-128-ctr",
+      "params": {
+        "iv": "95004433684ea23a22aa6d75f1821161"
+      },
+      "message": "ad94bbc28188956edf5db22dbe181ca07f0e5ac6b7d5c8b980f74d5380db01b6"
+    }
+  },
+  "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+  "path": "m/12381/60/0/0",
+  "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+  "version": 4
+}`
+
+func TestDecryptKey_EIP2335(t *testing.T) {
+	key, err := DecryptKey([]byte(eip2335Keystore), "testpassword")
+	require.NoError(t, err)
+	assert.Equal(t, "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f", hex.EncodeToString(key.SecretKey.Marshal()))
+	assert.Equal(t, "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07", hex.EncodeToString(key.PublicKey.Marshal()))
+	assert.Equal(t, "1d85ae20-35c5-4611-98e8-aa14a633906f", key.ID.String())
+
+	_, err = DecryptKey([]byte(eip2335Keystore), "wrong")
+	assert.Equal(t, ErrDecrypt, err)
+}
+
+func TestDecryptKey_EIP2335RecordsKDFDuration(t *testing.T) {
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.DebugLevel)
+	defer logrus.SetLevel(level)
+	hook := logTest.NewGlobal()
+
+	_, err := DecryptKey([]byte(eip2335Keystore), "testpassword")
+	require.NoError(t, err)
+
+	require.LogsContain(t, hook, "Derived keystore decryption key")
+	assert.Equal(t, pbkdf2KDF, hook.LastEntry().Data["kdf"])
+}
+
+func TestDecryptKey_EIP2335PubkeyMismatch(t *testing.T) {
+	other, err := NewKey()
+	require.NoError(t, err)
+	k := new(eip2335KeystoreJSON)
+	require.NoError(t, json.Unmarshal([]byte(eip2335Keystore), k))
+	k.Pubkey = hex.EncodeToString(other.PublicKey.Marshal())
+	keyJSON, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	_, err = DecryptKey(keyJSON, "testpassword")
+	assert.ErrorContains(t, "does not match the decrypted secret key", err)
+}
+
+func TestRefreshKeystoreIV(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	ks := &Keystore{
+		scryptN: LightScryptN,
+		scryptP: LightScryptP,
+	}
+	key, err := NewKey()
+	require.NoError(t, err)
+	require.NoError(t, ks.StoreKey(filename, key, "password"))
+
+	readIV := func() string {
+		keyJSON, err := ioutil.ReadFile(filename)
+		require.NoError(t, err)
+		k := new(encryptedKeyJSON)
+		require.NoError(t, json.Unmarshal(keyJSON, k))
+		return k.Crypto.CipherParams.IV
+	}
+	oldIV := readIV()
+
+	require.ErrorContains(t, ErrDecrypt.Error(), RefreshKeystoreIV(filename, "wrong"))
+	assert.Equal(t, oldIV, readIV())
+
+	require.NoError(t, RefreshKeystoreIV(filename, "password"))
+	assert.NotEqual(t, oldIV, readIV())
+	decryptedKey, err := ks.GetKey(filename, "password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), decryptedKey.SecretKey.Marshal())
+}
+
+func TestRefreshKeystoreIV_UnsupportedFormats(t *testing.T) {
+	dir := path.Join(t.TempDir(), "keystore")
+	eip2335File := path.Join(dir, "eip2335")
+	require.NoError(t, writeKeyFile(eip2335File, []byte(eip2335Keystore)))
+	assert.ErrorContains(t, "EIP-2335 keystores is not supported", RefreshKeystoreIV(eip2335File, "testpassword"))
+
+	key, err := NewKey()
+	require.NoError(t, err)
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	fields := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(keyJSON, &fields))
+	fields["description"] = "imported"
+	extendedJSON, err := json.Marshal(fields)
+	require.NoError(t, err)
+	extendedFile := path.Join(dir, "extended")
+	require.NoError(t, writeKeyFile(extendedFile, extendedJSON))
+	assert.ErrorContains(t, "unsupported keystore format", RefreshKeystoreIV(extendedFile, "password"))
+	unchanged, err := ioutil.ReadFile(extendedFile)
+	require.NoError(t, err)
+	assert.DeepEqual(t, extendedJSON, unchanged)
+}
+
+func TestRefreshKeystoreIV_WrongKeyScheme(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	key, err := NewKey()
+	require.NoError(t, err)
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	k := new(encryptedKeyJSON)
+	require.NoError(t, json.Unmarshal(keyJSON, k))
+	k.Crypto.Scheme = "secp256k1"
+	mismatchedJSON, err := json.Marshal(k)
+	require.NoError(t, err)
+	require.NoError(t, writeKeyFile(filename, mismatchedJSON))
+
+	assert.ErrorContains(t, ErrWrongKeyScheme.Error(), RefreshKeystoreIV(filename, "password"))
+	unchanged, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	assert.DeepEqual(t, mismatchedJSON, unchanged)
+}
+
+func TestTunePBKDF2Iterations(t *testing.T) {
+	assert.Equal(t, MinPBKDF2Iterations, TunePBKDF2Iterations(0))
+	assert.Equal(t, true, TunePBKDF2Iterations(time.Millisecond) >= MinPBKDF2Iterations)
+	assert.Equal(t, true, TunePBKDF2Iterations(time.Minute) > MinPBKDF2Iterations)
+}
+
+func TestEncryptDecryptKey_PBKDF2(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	keyJSON, err := EncryptKeyPBKDF2(key, "password", MinPBKDF2Iterations)
+	require.NoError(t, err)
+
+	k := new(encryptedKeyJSON)
+	require.NoError(t, json.Unmarshal(keyJSON, k))
+	assert.Equal(t, pbkdf2KDF, k.Crypto.KDF)
+
+	decryptedKey, err := DecryptKey(keyJSON, "password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), decryptedKey.SecretKey.Marshal())
+	_, err = DecryptKey(keyJSON, "wrong")
+	assert.Equal(t, ErrDecrypt, err)
+}
+
+func TestEncryptKeyPBKDF2_TooFewIterations(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	for _, c := range []int{-1, 0, 1, MinPBKDF2Iterations - 1} {
+		_, err := EncryptKeyPBKDF2(key, "password", c)
+		assert.ErrorContains(t, "below the minimum", err)
+	}
+}
+
+func TestDecryptKey_RecordsKDFDuration(t *testing.T) {
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.DebugLevel)
+	defer logrus.SetLevel(level)
+	hook := logTest.NewGlobal()
+
+	key, err := NewKey()
+	require.NoError(t, err)
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	_, err = DecryptKey(keyJSON, "password")
+	require.NoError(t, err)
+
+	require.LogsContain(t, hook, "Derived keystore decryption key")
+	entry := hook.LastEntry()
+	assert.Equal(t, keyHeaderKDF, entry.Data["kdf"])
+	duration, ok := entry.Data["duration"].(time.Duration)
+	require.Equal(t, true, ok)
+	assert.Equal(t, true, duration > 0)
+}
+
+func TestDecryptKey_WrongKeyScheme(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	k := new(encryptedKeyJSON)
+	require.NoError(t, json.Unmarshal(keyJSON, k))
+	assert.Equal(t, blsKeyScheme, k.Crypto.Scheme)
+
+	k.Crypto.Scheme = "secp256k1"
+	mismatchedJSON, err := json.Marshal(k)
+	require.NoError(t, err)
+	_, err = DecryptKey(mismatchedJSON, "password")
+	assert.ErrorContains(t, ErrWrongKeyScheme.Error(), err)
+
+	// A geth-style file has no scheme marker and an address instead of a public key.
+	var gethJSON map[string]interface{}
+	require.NoError(t, json.Unmarshal(keyJSON, &gethJSON))
+	delete(gethJSON, "publickey")
+	gethJSON["address"] = "008aeeda4d805471df9b2a5b0f38a0c3bcba786b"
+	gethJSON["version"] = 3
+	delete(gethJSON["crypto"].(map[string]interface{}), "scheme")
+	gethKeyJSON, err := json.Marshal(gethJSON)
+	require.NoError(t, err)
+	_, err = DecryptKey(gethKeyJSON, "password")
+	assert.Equal(t, ErrWrongKeyScheme, err)
+
+	// Older BLS keystores without the marker still decrypt.
+	k.Crypto.Scheme = ""
+	legacyJSON, err := json.Marshal(k)
+	require.NoError(t, err)
+	decryptedKey, err := DecryptKey(legacyJSON, "password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), decryptedKey.SecretKey.Marshal())
+}
+
+func TestEstimateDecryptCost(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	lowJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	highJSON, err := EncryptKey(key, "password", LightScryptN<<4, LightScryptP)
+	require.NoError(t, err)
+
+	low, err := EstimateDecryptCost(lowJSON)
+	require.NoError(t, err)
+	high, err := EstimateDecryptCost(highJSON)
+	require.NoError(t, err)
+	assert.Equal(t, true, high > low, "expected %v > %v", high, low)
+
+	pbkdf2JSON, err := EncryptKeyPBKDF2(key, "password", MinPBKDF2Iterations)
+	require.NoError(t, err)
+	cost, err := EstimateDecryptCost(pbkdf2JSON)
+	require.NoError(t, err)
+	assert.Equal(t, true, cost > 0)
+}
+
+func TestEstimateDecryptCost_InvalidParams(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	scryptJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	pbkdf2JSON, err := EncryptKeyPBKDF2(key, "password", MinPBKDF2Iterations)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		keyJSON []byte
+		param   string
+		value   interface{}
+		wantErr string
+	}{
+		{name: "zero c", keyJSON: pbkdf2JSON, param: "c", value: 0, wantErr: "invalid PBKDF2 iteration count"},
+		{name: "negative c", keyJSON: pbkdf2JSON, param: "c", value: -1, wantErr: "invalid PBKDF2 iteration count"},
+		{name: "missing c", keyJSON: pbkdf2JSON, param: "c", wantErr: "KDF parameter c is missing"},
+		{name: "fractional c", keyJSON: pbkdf2JSON, param: "c", value: 1.5, wantErr: "not a valid integer"},
+		{name: "string c", keyJSON: pbkdf2JSON, param: "c", value: "1", wantErr: "unexpected type"},
+		{name: "zero r", keyJSON: scryptJSON, param: "r", value: 0, wantErr: "invalid scrypt parameters"},
+		{name: "one n", keyJSON: scryptJSON, param: "n", value: 1, wantErr: "invalid scrypt parameters"},
+		{name: "zero p", keyJSON: scryptJSON, param: "p", value: 0, wantErr: "invalid scrypt parameters"},
+		{name: "missing n", keyJSON: scryptJSON, param: "n", wantErr: "KDF parameter n is missing"},
+		{name: "n not a power of two", keyJSON: scryptJSON, param: "n", value: 1000, wantErr: "invalid scrypt parameters"},
+		{name: "r*p too large", keyJSON: scryptJSON, param: "r", value: 1 << 29, wantErr: "invalid scrypt parameters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := new(encryptedKeyJSON)
+			require.NoError(t, json.Unmarshal(tt.keyJSON, k))
+			if tt.value == nil {
+				delete(k.Crypto.KDFParams, tt.param)
+			} else {
+				k.Crypto.KDFParams[tt.param] = tt.value
+			}
+			keyJSON, err := json.Marshal(k)
+			require.NoError(t, err)
+			_, err = EstimateDecryptCost(keyJSON)
+			assert.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestEstimateDecryptCost_Saturates(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	scryptJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+	k := new(encryptedKeyJSON)
+	require.NoError(t, json.Unmarshal(scryptJSON, k))
+	// Valid for scrypt, but the scaled probe time does not fit in a time.Duration.
+	k.Crypto.KDFParams["n"] = 1 << 30
+	k.Crypto.KDFParams["r"] = 1 << 5
+	k.Crypto.KDFParams["p"] = 1 << 24
+	keyJSON, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	cost, err := EstimateDecryptCost(keyJSON)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(math.MaxInt64), cost)
+}
+
+func TestEstimateDecryptCost_EIP2335(t *testing.T) {
+	cost, err := EstimateDecryptCost([]byte(eip2335Keystore))
+	require.NoError(t, err)
+	assert.Equal(t, true, cost > 0)
+
+	_, err = EstimateDecryptCost([]byte(`{"crypto": {"checksum": {}}, "version": 4}`))
+	assert.ErrorContains(t, "EIP-2335 keystore has no KDF module", err)
+}
+
+func BenchmarkDecryptKey_Scrypt(b *testing.B) {
+	key, err := NewKey()
+	require.NoError(b, err)
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	require.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := DecryptKey(keyJSON, "password")
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkDecryptKey_PBKDF2(b *testing.B) {
+	key, err := NewKey()
+	require.NoError(b, err)
+	keyJSON, err := EncryptKeyPBKDF2(key, "password", MinPBKDF2Iterations)
+	require.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := DecryptKey(keyJSON, "password")
+		require.NoError(b, err)
+	}
+}
+
+func TestConvertToEIP2335(t *testing.T) {
+	filename := path.Join(t.TempDir(), "keystore", "file")
+	ks := &Keystore{
+		scryptN: LightScryptN,
+		scryptP: LightScryptP,
+	}
+	key, err := NewKey()
+	require.NoError(t, err)
+	require.NoError(t, ks.StoreKey(filename, key, "password"))
+
+	_, err = ConvertToEIP2335(filename, "wrong")
+	assert.Equal(t, ErrDecrypt, err)
+
+	enc, err := ConvertToEIP2335(filename, "password")
+	require.NoError(t, err)
+	converted := new(eip2335KeystoreJSON)
+	require.NoError(t, json.Unmarshal(enc, converted))
+	assert.Equal(t, uint(4), converted.Version)
+	assert.Equal(t, key.ID.String(), converted.ID)
+	assert.Equal(t, hex.EncodeToString(key.PublicKey.Marshal()), converted.Pubkey)
+
+	secretKey, err := keystorev4.New().Decrypt(converted.Crypto, "password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, key.SecretKey.Marshal(), secretKey)
+}
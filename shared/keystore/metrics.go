@@ -0,0 +1,16 @@
+package keystore
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	kdfDerivationDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "keystore_kdf_derivation_seconds",
+			Help:    "Time taken to derive a keystore decryption key from a password",
+			Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30},
+		},
+	)
+)
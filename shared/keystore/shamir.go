@@ -0,0 +1,108 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+const (
+	// maxSecretKeyShares is the number of distinct non-zero share indices a
+	// single byte prefix can encode.
+	maxSecretKeyShares = 255
+	secretKeyLength    = 32
+	secretKeyShareLen  = 1 + secretKeyLength
+)
+
+var (
+	// ErrInvalidShare is returned when a secret key share is malformed or duplicated.
+	ErrInvalidShare = errors.New("invalid secret key share")
+	curveOrder, _   = new(big.Int).SetString(bls.CurveOrder, 10)
+)
+
+// SplitSecretKey splits a secret key into parts shares using Shamir's Secret
+// Sharing over the BLS12-381 scalar field, so that any threshold of them
+// recover the key while fewer reveal nothing about it. Each share is a one
+// byte index followed by the 32 byte big-endian evaluation at that index.
+func SplitSecretKey(key bls.SecretKey, parts, threshold int) ([][]byte, error) {
+	if threshold < 2 || threshold > parts || parts > maxSecretKeyShares {
+		return nil, fmt.Errorf("invalid share parameters: %d-of-%d, need 2 <= threshold <= parts <= %d",
+			threshold, parts, maxSecretKeyShares)
+	}
+	// The constant term of the polynomial is the secret itself.
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = new(big.Int).SetBytes(key.Marshal())
+	for i := 1; i < threshold; i++ {
+		c, err := rand.Int(rand.Reader, curveOrder)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = c
+	}
+
+	shares := make([][]byte, parts)
+	for i := 0; i < parts; i++ {
+		x := big.NewInt(int64(i + 1))
+		// Evaluate the polynomial at x using Horner's method.
+		y := new(big.Int)
+		for j := threshold - 1; j >= 0; j-- {
+			y.Mul(y, x)
+			y.Add(y, coefficients[j])
+			y.Mod(y, curveOrder)
+		}
+		share := make([]byte, secretKeyShareLen)
+		share[0] = byte(i + 1)
+		y.FillBytes(share[1:])
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// RecoverSecretKey rebuilds a secret key from shares produced by SplitSecretKey,
+// using Lagrange interpolation at zero. Supplying fewer shares than the split
+// threshold yields an unrelated key or an error, never the original.
+func RecoverSecretKey(shares [][]byte) (bls.SecretKey, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no secret key shares provided")
+	}
+	xs := make([]*big.Int, len(shares))
+	ys := make([]*big.Int, len(shares))
+	seen := make(map[byte]bool, len(shares))
+	for i, share := range shares {
+		if len(share) != secretKeyShareLen || share[0] == 0 {
+			return nil, fmt.Errorf("%w: share %d", ErrInvalidShare, i)
+		}
+		if seen[share[0]] {
+			return nil, fmt.Errorf("%w: duplicate index %d", ErrInvalidShare, share[0])
+		}
+		seen[share[0]] = true
+		xs[i] = big.NewInt(int64(share[0]))
+		ys[i] = new(big.Int).SetBytes(share[1:])
+	}
+
+	secret := new(big.Int)
+	for i := range shares {
+		// basis = prod_{j != i} x_j / (x_j - x_i) mod r.
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			num.Mul(num, xs[j])
+			num.Mod(num, curveOrder)
+			diff := new(big.Int).Sub(xs[j], xs[i])
+			den.Mul(den, diff)
+			den.Mod(den, curveOrder)
+		}
+		basis := num.Mul(num, den.ModInverse(den, curveOrder))
+		basis.Mul(basis, ys[i])
+		secret.Add(secret, basis)
+		secret.Mod(secret, curveOrder)
+	}
+	keyBytes := make([]byte, secretKeyLength)
+	secret.FillBytes(keyBytes)
+	return bls.SecretKeyFromBytes(keyBytes)
+}
@@ -0,0 +1,64 @@
+package keystore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSplitRecoverSecretKey(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := SplitSecretKey(key, 5, 3)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(shares))
+
+	// Any threshold-sized subset recovers the key.
+	subsets := [][]int{{0, 1, 2}, {0, 2, 4}, {4, 3, 1}, {1, 2, 3, 4}, {0, 1, 2, 3, 4}}
+	for _, subset := range subsets {
+		picked := make([][]byte, 0, len(subset))
+		for _, i := range subset {
+			picked = append(picked, shares[i])
+		}
+		recovered, err := RecoverSecretKey(picked)
+		require.NoError(t, err)
+		assert.DeepEqual(t, key.Marshal(), recovered.Marshal())
+	}
+
+	// Fewer than threshold shares do not.
+	recovered, err := RecoverSecretKey(shares[:2])
+	if err == nil {
+		assert.Equal(t, false, bytes.Equal(key.Marshal(), recovered.Marshal()))
+	}
+}
+
+func TestSplitSecretKey_InvalidParameters(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	_, err = SplitSecretKey(key, 3, 4)
+	assert.ErrorContains(t, "invalid share parameters", err)
+	_, err = SplitSecretKey(key, 3, 1)
+	assert.ErrorContains(t, "invalid share parameters", err)
+	_, err = SplitSecretKey(key, 256, 2)
+	assert.ErrorContains(t, "invalid share parameters", err)
+}
+
+func TestRecoverSecretKey_InvalidShares(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := SplitSecretKey(key, 3, 2)
+	require.NoError(t, err)
+
+	_, err = RecoverSecretKey(nil)
+	assert.ErrorContains(t, "no secret key shares provided", err)
+	_, err = RecoverSecretKey([][]byte{shares[0], shares[0]})
+	assert.ErrorContains(t, ErrInvalidShare.Error(), err)
+	_, err = RecoverSecretKey([][]byte{shares[0], shares[1][:10]})
+	assert.ErrorContains(t, ErrInvalidShare.Error(), err)
+	zeroIndex := append([]byte{0}, shares[1][1:]...)
+	_, err = RecoverSecretKey([][]byte{shares[0], zeroIndex})
+	assert.ErrorContains(t, ErrInvalidShare.Error(), err)
+}
@@ -23,6 +23,7 @@ import (
 	"crypto/cipher"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/shared/bls"
@@ -49,6 +50,24 @@ func ensureInt(x interface{}) int {
 	return res
 }
 
+// kdfIntParam reads an integer KDF parameter from an untrusted keystore file,
+// returning an error where ensureInt would panic.
+func kdfIntParam(params map[string]interface{}, name string) (int, error) {
+	switch v := params[name].(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return 0, fmt.Errorf("KDF parameter %s is not a valid integer: %v", name, v)
+		}
+		return int(v), nil
+	case nil:
+		return 0, fmt.Errorf("KDF parameter %s is missing", name)
+	default:
+		return 0, fmt.Errorf("KDF parameter %s has unexpected type %T", name, v)
+	}
+}
+
 // keyFileName implements the naming convention for keyfiles:
 // UTC--<created_at UTC ISO8601>-<first 8 character of address hex>
 func keyFileName(pubkey bls.PublicKey) string {
@@ -66,3 +85,13 @@ func toISO8601(t time.Time) string {
 	}
 	return fmt.Sprintf("%04d-%02d-%02dT%02d-%02d-%02d.%09d%s", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), tz)
 }
+
+// scaleDuration multiplies d by factor, saturating at the largest representable
+// duration instead of overflowing.
+func scaleDuration(d time.Duration, factor float64) time.Duration {
+	scaled := float64(d) * factor
+	if scaled >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(scaled)
+}
@@ -49,6 +49,9 @@ func (mockSignature) Marshal() []byte {
 func (m mockSignature) Copy() bls.Signature {
 	return m
 }
+func (m mockSignature) Aggregate(bls.Signature) bls.Signature {
+	return m
+}
 
 func setup(t *testing.T) (*validator, *mocks, bls.SecretKey, func()) {
 	validatorKey, err := bls.RandKey()